@@ -0,0 +1,7 @@
+// Package circuits composes existing ckks.Evaluator primitives into small, reusable homomorphic operators
+// that are themselves too common to leave to every caller to re-derive, but too specific to belong on
+// ckks.Evaluator directly. The first two such operators, EqualityMask and CountAboveThreshold, together
+// implement encrypted attribute matching followed by a single-bit "is the count above threshold?" output,
+// e.g. for private database exploration: match an encrypted query attribute against every record in a
+// batch, then reveal only whether the number of matches clears a threshold.
+package circuits
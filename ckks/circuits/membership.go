@@ -0,0 +1,75 @@
+package circuits
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v4/ckks"
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+	"github.com/tuneinsight/lattigo/v4/utils/bignum"
+)
+
+// EqualityMask approximates, independently in every slot, the indicator function `x == target` by evaluating
+// a low-depth Chebyshev approximation of a Gaussian bump centered at target, and returns the result on a new
+// ciphertext. Slots whose value is within roughly `precision` of target evaluate close to 1; slots further
+// away evaluate close to 0. Smaller values of precision track the indicator more sharply, at the cost of a
+// higher-degree, and therefore deeper, polynomial.
+//
+// domain must be a [lo, hi] bound that every slot of ctIn is guaranteed to fall within (e.g. the known range
+// of the matched attribute across all records). A Chebyshev approximation is only accurate inside its fit
+// interval; outside of it the polynomial is unconstrained and can diverge arbitrarily, so domain is fit in
+// full rather than just a window around target — callers driving CountAboveThreshold over many
+// mostly-non-matching records must size domain to cover every record's value, not just ones near target, or
+// non-matching slots will corrupt the InnerSum-based count instead of safely evaluating to ~0.
+//
+// target is real-valued: `|x-target|^2` is not holomorphic in a complex x (it depends on conj(x)), so no
+// single polynomial in x can approximate it across the complex plane. EqualityMask is meant for ciphertexts
+// encoding real attributes in each slot.
+//
+// The required multiplicative depth can be queried ahead of time with EqualityMaskDepth(precision, domain).
+func EqualityMask(eval *ckks.Evaluator, ctIn *rlwe.Ciphertext, target float64, precision float64, domain [2]float64) (ctOut *rlwe.Ciphertext, err error) {
+
+	if precision <= 0 {
+		return nil, fmt.Errorf("cannot EqualityMask: precision must be > 0, got %f", precision)
+	}
+	if domain[0] >= domain[1] {
+		return nil, fmt.Errorf("cannot EqualityMask: domain[0]=%f must be < domain[1]=%f", domain[0], domain[1])
+	}
+
+	poly := bumpPolynomial(target, precision, domain)
+
+	if ctOut, err = eval.Polynomial(ctIn, poly, ctIn.Scale); err != nil {
+		return nil, fmt.Errorf("cannot EqualityMask: %w", err)
+	}
+
+	return ctOut, nil
+}
+
+// EqualityMaskDepth returns the multiplicative depth that EqualityMask consumes for the given precision and
+// domain.
+func EqualityMaskDepth(precision float64, domain [2]float64) int {
+	return bumpPolynomial(0, precision, domain).Depth()
+}
+
+// bumpPolynomial returns a Chebyshev approximation, fit over the whole of domain (not just a window around
+// target), of the Gaussian bump `x -> exp(-(x-target)^2 / (2*precision^2))`, which is ~1 at target and
+// decays to ~0 within a few multiples of precision. The node count scales with both the domain width and the
+// requested precision, since approximating a narrow bump accurately over a wide interval requires a
+// higher-degree polynomial than approximating it over a window already close to its size.
+func bumpPolynomial(target float64, precision float64, domain [2]float64) bignum.Polynomial {
+
+	bump := func(x complex128) (complex128, error) {
+		d := real(x) - target
+		return complex(math.Exp(-(d*d)/(2*precision*precision)), 0), nil
+	}
+
+	width := domain[1] - domain[0]
+
+	interval := bignum.Interval{
+		Nodes: 7 + int(math.Ceil(math.Log2(1/precision))) + int(math.Ceil(math.Log2(1+width/precision))),
+		A:     *bignum.NewFloat(domain[0], 53),
+		B:     *bignum.NewFloat(domain[1], 53),
+	}
+
+	return bignum.ChebyshevApproximation(bump, interval)
+}
@@ -0,0 +1,54 @@
+package circuits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tuneinsight/lattigo/v4/ckks"
+)
+
+// TestEqualityMaskRealTarget checks that EqualityMask, fit over the full declared domain, evaluates to ~1 on
+// a slot matching target and ~0 on slots far from it, and that EqualityMaskDepth is consistent with the
+// depth actually consumed by the returned ciphertext.
+func TestEqualityMaskRealTarget(t *testing.T) {
+
+	params, err := ckks.NewParametersFromLiteral(ckks.TestParametersLiteral)
+	require.NoError(t, err)
+
+	ecd := ckks.NewEncoder(params)
+	kgen := ckks.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	enc := ckks.NewEncryptor(params, sk)
+	dec := ckks.NewDecryptor(params, sk)
+	eval := ckks.NewEvaluator(params, nil)
+
+	slots := params.MaxSlots()
+	values := make([]float64, slots)
+	for i := range values {
+		values[i] = float64(i % 16)
+	}
+
+	pt := ckks.NewPlaintext(params, params.MaxLevel())
+	require.NoError(t, ecd.Encode(values, pt))
+
+	ctIn, err := enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	const precision = 0.5
+	domain := [2]float64{0, 15}
+
+	ctOut, err := EqualityMask(eval, ctIn, 3.0, precision, domain)
+	require.NoError(t, err)
+	require.Equal(t, ctIn.Level()-EqualityMaskDepth(precision, domain), ctOut.Level())
+
+	have := make([]float64, slots)
+	require.NoError(t, ecd.Decode(dec.DecryptNew(ctOut), have))
+
+	for i, v := range values {
+		if v == 3.0 {
+			require.InDelta(t, 1.0, have[i], 0.1)
+		} else {
+			require.InDelta(t, 0.0, have[i], 0.1)
+		}
+	}
+}
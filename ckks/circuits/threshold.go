@@ -0,0 +1,67 @@
+package circuits
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v4/ckks"
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+	"github.com/tuneinsight/lattigo/v4/utils/bignum"
+)
+
+// thresholdSlope controls the steepness of the logistic step used to approximate `count >= threshold`: the
+// function rises from ~0.05 to ~0.95 over a window of 6/thresholdSlope centered on threshold. It is kept
+// fixed rather than exposed, since callers tune sharpness through EqualityMask's precision upstream instead.
+const thresholdSlope = 4.0
+
+// CountAboveThreshold aggregates the per-slot indicator values of ctIn (typically the output of
+// EqualityMask, summed across all matching attributes of a record) across every slot via Evaluator.InnerSum,
+// then applies a smooth logistic step approximating `count >= threshold` to the aggregate, yielding a
+// ciphertext whose slots all hold ~1 if the count clears threshold and ~0 otherwise.
+//
+// ctIn is assumed to encrypt one value per slot, with logSlots the base-2 log of the number of slots to sum
+// over. The required depth and rotation keys can be queried ahead of time with CountAboveThresholdDepth and
+// GaloisElementsForCountAboveThreshold.
+func CountAboveThreshold(eval *ckks.Evaluator, ctIn *rlwe.Ciphertext, logSlots int, threshold float64) (ctOut *rlwe.Ciphertext, err error) {
+
+	ctOut = ckks.NewCiphertext(eval.Parameters(), 1, ctIn.Level())
+	eval.InnerSum(ctIn, 1, 1<<logSlots, ctOut)
+
+	poly := stepPolynomial(threshold)
+
+	if ctOut, err = eval.Polynomial(ctOut, poly, ctOut.Scale); err != nil {
+		return nil, fmt.Errorf("cannot CountAboveThreshold: %w", err)
+	}
+
+	return ctOut, nil
+}
+
+// CountAboveThresholdDepth returns the multiplicative depth that CountAboveThreshold consumes (InnerSum is
+// depth-free; only the final step-function evaluation consumes levels).
+func CountAboveThresholdDepth(threshold float64) int {
+	return stepPolynomial(threshold).Depth()
+}
+
+// GaloisElementsForCountAboveThreshold returns the Galois elements whose keys must be generated for
+// Evaluator.InnerSum to be usable by CountAboveThreshold over 2^logSlots slots.
+func GaloisElementsForCountAboveThreshold(params rlwe.Parameters, logSlots int) []uint64 {
+	return params.GaloisElementsForInnerSum(1, 1<<logSlots)
+}
+
+// stepPolynomial returns a Chebyshev approximation of the logistic step function
+// `x -> 1 / (1 + exp(-thresholdSlope*(x-threshold)))`, which is ~0 well below threshold, ~1 well above it,
+// and transitions smoothly in between.
+func stepPolynomial(threshold float64) bignum.Polynomial {
+
+	step := func(x complex128) (complex128, error) {
+		return complex(1/(1+math.Exp(-thresholdSlope*(real(x)-threshold))), 0), nil
+	}
+
+	interval := bignum.Interval{
+		Nodes: 31,
+		A:     *bignum.NewFloat(threshold-6/thresholdSlope, 53),
+		B:     *bignum.NewFloat(threshold+6/thresholdSlope, 53),
+	}
+
+	return bignum.ChebyshevApproximation(step, interval)
+}
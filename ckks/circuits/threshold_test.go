@@ -0,0 +1,64 @@
+package circuits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tuneinsight/lattigo/v4/ckks"
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+)
+
+// TestCountAboveThresholdRoundTrip checks that CountAboveThreshold sums the per-slot indicator values of ctIn
+// and evaluates to ~1 when the sum clears threshold and ~0 when it doesn't.
+func TestCountAboveThresholdRoundTrip(t *testing.T) {
+
+	params, err := ckks.NewParametersFromLiteral(ckks.TestParametersLiteral)
+	require.NoError(t, err)
+
+	ecd := ckks.NewEncoder(params)
+	kgen := ckks.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	enc := ckks.NewEncryptor(params, sk)
+	dec := ckks.NewDecryptor(params, sk)
+
+	logSlots := params.LogMaxSlots()
+	slots := 1 << logSlots
+
+	galEls := GaloisElementsForCountAboveThreshold(params, logSlots)
+	gks := kgen.GenGaloisKeysNew(galEls, sk)
+	evk := rlwe.NewMemEvaluationKeySet(nil, gks...)
+	eval := ckks.NewEvaluator(params, evk)
+
+	const threshold = 3.0
+
+	encryptIndicators := func(nbOnes int) *rlwe.Ciphertext {
+		values := make([]float64, slots)
+		for i := 0; i < nbOnes; i++ {
+			values[i] = 1.0
+		}
+
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, ecd.Encode(values, pt))
+
+		ctIn, err := enc.EncryptNew(pt)
+		require.NoError(t, err)
+
+		return ctIn
+	}
+
+	decryptFirstSlot := func(ctOut *rlwe.Ciphertext) float64 {
+		have := make([]float64, slots)
+		require.NoError(t, ecd.Decode(dec.DecryptNew(ctOut), have))
+		return have[0]
+	}
+
+	ctAbove := encryptIndicators(5)
+	ctOutAbove, err := CountAboveThreshold(eval, ctAbove, logSlots, threshold)
+	require.NoError(t, err)
+	require.InDelta(t, 1.0, decryptFirstSlot(ctOutAbove), 0.1)
+
+	ctBelow := encryptIndicators(1)
+	ctOutBelow, err := CountAboveThreshold(eval, ctBelow, logSlots, threshold)
+	require.NoError(t, err)
+	require.InDelta(t, 0.0, decryptFirstSlot(ctOutBelow), 0.1)
+}
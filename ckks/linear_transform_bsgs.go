@@ -0,0 +1,236 @@
+package ckks
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+	"github.com/tuneinsight/lattigo/v4/rlwe/ringqp"
+	"github.com/tuneinsight/lattigo/v4/utils/bignum"
+)
+
+// NewLinearTransformBSGS encodes the diagonals of a linear transform using the baby-step/giant-step (BSGS)
+// decomposition: a non-zero diagonal of index i is re-indexed as i = g*N1 + b with 0 <= b < N1, pre-rotated
+// left by -g*N1 positions before encoding, and stored under the key g*N1+b in the returned
+// rlwe.LinearTransform's Vec. Evaluator.LinearTransformBSGS then only needs at most the N1-1 "baby-step"
+// rotations {1, ..., N1-1}, computed once and shared across every giant step, plus one "giant-step" rotation per used
+// value of g — as opposed to one rotation per non-zero diagonal for the naive method reached through
+// Evaluator.LinearTransform / EncodeLinearTransformDiagonal.
+//
+// If N1 <= 0, it is set by FindBestBSGSRatio to the value minimizing N1 + ceil(#diagonals/N1), an estimate of
+// the number of rotations the evaluation will require.
+func NewLinearTransformBSGS[T float64 | complex128 | *big.Float | *bignum.Complex](ecd *Encoder, diagonals map[int][]T, logSlots int, scale rlwe.Scale, N1 int) (lt rlwe.LinearTransform, err error) {
+
+	slots := 1 << logSlots
+
+	l := NewLinearTransformEncoder(ecd, diagonals).(LinearTransformEncoder[T])
+	nonZeroDiags := l.NonZeroDiagonals()
+
+	if N1 <= 0 {
+		N1 = FindBestBSGSRatio(nonZeroDiags, slots, 1)
+	}
+
+	params := ecd.Parameters().Parameters
+	levelQ, levelP := params.MaxLevel(), params.MaxLevelP()
+	ringQP := params.RingQP().AtLevel(levelQ, levelP)
+
+	lt = rlwe.LinearTransform{
+		LogSlots: logSlots,
+		N1:       N1,
+		Level:    levelQ,
+		Scale:    scale,
+		Vec:      make(map[int]ringqp.Poly),
+	}
+
+	for _, i := range nonZeroDiags {
+		idx := i
+		if idx < 0 {
+			idx += slots
+		}
+
+		g := (idx / N1) * N1
+		b := idx - g
+
+		poly := ringQP.NewPoly()
+		if err = l.EncodeLinearTransformDiagonal(i, -g, scale, logSlots, poly); err != nil {
+			return rlwe.LinearTransform{}, err
+		}
+
+		lt.Vec[g+b] = poly
+	}
+
+	return lt, nil
+}
+
+// GaloisElementsForLinearTransformBSGS returns the list of Galois elements needed to evaluate, via
+// Evaluator.LinearTransformBSGS, a linear transform whose non-zero diagonals are nonZeroDiags, encoded with
+// NewLinearTransformBSGS using the baby-step count N1: one baby-step rotation per distinct baby step b and one
+// giant-step rotation {g*N1} per distinct giant-step index g used by a non-zero diagonal. Steps of 0 are
+// included when a diagonal uses them.
+func GaloisElementsForLinearTransformBSGS(params rlwe.Parameters, nonZeroDiags []int, logSlots, N1 int) (galEls []uint64) {
+
+	slots := 1 << logSlots
+
+	_, n1Rotations, n2Rotations := bsgsIndex(nonZeroDiags, slots, N1)
+
+	galEls = make([]uint64, 0, len(n1Rotations)+len(n2Rotations))
+	for _, i := range n1Rotations {
+		galEls = append(galEls, params.GaloisElement(i))
+	}
+	for _, g := range n2Rotations {
+		galEls = append(galEls, params.GaloisElement(g))
+	}
+
+	return galEls
+}
+
+// FindBestBSGSRatio returns, among 1 and the powers of two up to slots (and up to maxN1 when maxN1 > 1) tested
+// as candidate baby-step counts, the value of N1 minimizing N1 + ceil(#nonZeroDiags/N1), an estimate of the
+// number of rotations Evaluator.LinearTransformBSGS will perform.
+func FindBestBSGSRatio(nonZeroDiags []int, slots, maxN1 int) (N1 int) {
+
+	bestN1, bestCost := 1, 1+len(nonZeroDiags)
+
+	for n1 := 2; n1 <= slots; n1 <<= 1 {
+
+		if maxN1 > 1 && n1 > maxN1 {
+			break
+		}
+
+		if cost := n1 + (len(nonZeroDiags)+n1-1)/n1; cost < bestCost {
+			bestN1, bestCost = n1, cost
+		}
+	}
+
+	return bestN1
+}
+
+// bsgsIndex splits each diagonal index i in nonZeroDiags as i = g*N1 + b (0 <= b < N1, reduced mod slots),
+// and returns: index, mapping each giant step g to the sorted list of baby steps b it is paired with; n1,
+// the sorted list of distinct baby steps that must be rotated-to once (the hoisted rotations); and n2, the
+// sorted list of distinct giant steps that must be rotated-to once each.
+func bsgsIndex(nonZeroDiags []int, slots, N1 int) (index map[int][]int, n1, n2 []int) {
+
+	index = make(map[int][]int)
+	n1Set := make(map[int]bool)
+	n2Set := make(map[int]bool)
+
+	for _, i := range nonZeroDiags {
+		idx := i
+		if idx < 0 {
+			idx += slots
+		}
+		idx &= (slots - 1)
+
+		g := (idx / N1) * N1
+		b := idx - g
+
+		if !contains(index[g], b) {
+			index[g] = append(index[g], b)
+		}
+
+		n1Set[b] = true
+		n2Set[g] = true
+	}
+
+	for g, bs := range index {
+		sort.Ints(bs)
+		index[g] = bs
+	}
+
+	for b := range n1Set {
+		n1 = append(n1, b)
+	}
+	sort.Ints(n1)
+
+	for g := range n2Set {
+		n2 = append(n2, g)
+	}
+	sort.Ints(n2)
+
+	return index, n1, n2
+}
+
+// contains reports whether slice s contains v.
+func contains(s []int, v int) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// LinearTransformBSGS evaluates a linear transform encoded by NewLinearTransformBSGS on ctIn and writes the
+// result to ctOut, using the baby-step/giant-step algorithm: the input is rotated once by each baby step in
+// {1, ..., N1-1} that a non-zero diagonal uses, with the automorphism decomposition of ctIn shared (hoisted) across all of them, then
+// for every giant step g the matching baby-step ciphertexts are combined with their encoded diagonals and
+// the accumulated sum is rotated by g*N1 once before being added to ctOut. The Galois keys for the elements
+// returned by GaloisElementsForLinearTransformBSGS must have been generated beforehand.
+func (eval *Evaluator) LinearTransformBSGS(ctIn *rlwe.Ciphertext, linearTransform rlwe.LinearTransform, ctOut *rlwe.Ciphertext) (err error) {
+
+	params := eval.params
+	levelQ := ctIn.Level()
+	levelP := params.MaxLevelP()
+
+	index, _, n2Rotations := bsgsIndex(nonZeroDiagonals(linearTransform), 1<<linearTransform.LogSlots, linearTransform.N1)
+
+	if len(n2Rotations) == 0 {
+		// Degenerate transform with no non-zero diagonals: the result is the zero ciphertext.
+		*ctOut = *NewCiphertext(params, ctOut.Degree(), levelQ)
+		ctOut.Scale = ctIn.Scale.Mul(linearTransform.Scale)
+		return nil
+	}
+
+	// Shared decomposition of ctIn's Value[1], reused for every baby-step rotation (hoisting).
+	decompQP := eval.DecomposeNTT(levelQ, levelP, params.PCount(), ctIn.Value[1])
+
+	babySteps := map[int]*rlwe.Ciphertext{0: ctIn}
+	for _, bs := range index {
+		for _, b := range bs {
+			if _, ok := babySteps[b]; !ok {
+				babySteps[b] = eval.AutomorphismHoistedNew(levelQ, ctIn, decompQP, params.GaloisElement(b))
+			}
+		}
+	}
+
+	ctOut.Resize(ctOut.Degree(), levelQ)
+	ctOut.Scale = ctIn.Scale.Mul(linearTransform.Scale)
+
+	var acc *rlwe.Ciphertext
+	for _, g := range n2Rotations {
+
+		tmp := NewCiphertext(params, 1, levelQ)
+		for _, b := range index[g] {
+			if err = eval.MulThenAdd(babySteps[b], linearTransform.Vec[g+b], tmp); err != nil {
+				return fmt.Errorf("cannot LinearTransformBSGS: %w", err)
+			}
+		}
+
+		if g != 0 {
+			if err = eval.Automorphism(tmp, params.GaloisElement(g), tmp); err != nil {
+				return fmt.Errorf("cannot LinearTransformBSGS: %w", err)
+			}
+		}
+
+		if acc == nil {
+			acc = tmp
+		} else {
+			eval.Add(acc, tmp, acc)
+		}
+	}
+
+	*ctOut = *acc
+
+	return nil
+}
+
+// nonZeroDiagonals returns the keys of a BSGS-encoded rlwe.LinearTransform's Vec.
+func nonZeroDiagonals(lt rlwe.LinearTransform) []int {
+	keys := make([]int, 0, len(lt.Vec))
+	for k := range lt.Vec {
+		keys = append(keys, k)
+	}
+	return keys
+}
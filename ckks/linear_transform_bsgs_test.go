@@ -0,0 +1,101 @@
+package ckks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+)
+
+// TestLinearTransformBSGSDegenerate checks that evaluating a BSGS-encoded linear transform with no non-zero
+// diagonals returns a zeroed ciphertext instead of panicking on a nil dereference.
+func TestLinearTransformBSGSDegenerate(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(TestParametersLiteral)
+	require.NoError(t, err)
+
+	ecd := NewEncoder(params)
+	lt, err := NewLinearTransformBSGS[complex128](ecd, map[int][]complex128{}, params.LogMaxSlots(), rlwe.NewScale(1), 1)
+	require.NoError(t, err)
+
+	kgen := NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	enc := NewEncryptor(params, sk)
+
+	pt := NewPlaintext(params, params.MaxLevel())
+	ctIn, err := enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	eval := NewEvaluator(params, nil)
+	ctOut := NewCiphertext(params, 1, params.MaxLevel())
+
+	require.NotPanics(t, func() {
+		require.NoError(t, eval.LinearTransformBSGS(ctIn, lt, ctOut))
+	})
+}
+
+// TestLinearTransformBSGSCorrectness checks that Evaluator.LinearTransformBSGS computes the same
+// matrix-vector product as directly evaluating the diagonals in the clear: for every slot s,
+// sum over non-zero diagonals i of diag_i[s] * values[(s+i) mod slots].
+func TestLinearTransformBSGSCorrectness(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(TestParametersLiteral)
+	require.NoError(t, err)
+
+	logSlots := params.LogMaxSlots()
+	slots := 1 << logSlots
+
+	values := make([]complex128, slots)
+	for i := range values {
+		values[i] = complex(float64(i+1), 0)
+	}
+
+	diagonals := map[int][]complex128{
+		0:  make([]complex128, slots),
+		1:  make([]complex128, slots),
+		-2: make([]complex128, slots),
+	}
+	for s := 0; s < slots; s++ {
+		diagonals[0][s] = complex(2, 0)
+		diagonals[1][s] = complex(0.5, 0)
+		diagonals[-2][s] = complex(0.25, 0)
+	}
+
+	want := make([]complex128, slots)
+	for s := 0; s < slots; s++ {
+		for i, diag := range diagonals {
+			want[s] += diag[s] * values[((s+i)%slots+slots)%slots]
+		}
+	}
+
+	ecd := NewEncoder(params)
+	lt, err := NewLinearTransformBSGS[complex128](ecd, diagonals, logSlots, rlwe.NewScale(1), 0)
+	require.NoError(t, err)
+
+	kgen := NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	enc := NewEncryptor(params, sk)
+	dec := NewDecryptor(params, sk)
+
+	pt := NewPlaintext(params, params.MaxLevel())
+	require.NoError(t, ecd.Encode(values, pt))
+
+	ctIn, err := enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	galEls := GaloisElementsForLinearTransformBSGS(params, nonZeroDiagonals(lt), logSlots, lt.N1)
+	gks := kgen.GenGaloisKeysNew(galEls, sk)
+	evk := rlwe.NewMemEvaluationKeySet(nil, gks...)
+	eval := NewEvaluator(params, evk)
+
+	ctOut := NewCiphertext(params, 1, params.MaxLevel())
+	require.NoError(t, eval.LinearTransformBSGS(ctIn, lt, ctOut))
+
+	have := make([]complex128, slots)
+	require.NoError(t, ecd.Decode(dec.DecryptNew(ctOut), have))
+
+	for s := range want {
+		require.InDelta(t, real(want[s]), real(have[s]), 1e-2)
+		require.InDelta(t, imag(want[s]), imag(have[s]), 1e-2)
+	}
+}
@@ -0,0 +1,147 @@
+package mhe
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v4/ring"
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+	"github.com/tuneinsight/lattigo/v4/rlwe/ringqp"
+)
+
+// EvaluationKeyShare is a party's share of a collectively generated rlwe.EvaluationKey switching from skIn to
+// skOut. It has the same row/row-pair shape as the final rlwe.GadgetCiphertext: the "a" part of every row is
+// the common, CRS-derived polynomial and is therefore identical across all parties' shares, while the "b"
+// part is this party's additive contribution and is what AggregateEvaluationKeyShares sums over.
+type EvaluationKeyShare struct {
+	rlwe.GadgetCiphertext
+}
+
+// BinarySize returns the size in bytes that the share occupies once marshalled.
+func (share *EvaluationKeyShare) BinarySize() int {
+	return share.GadgetCiphertext.MarshalBinarySize()
+}
+
+// MarshalBinary encodes the share on a newly allocated slice of bytes.
+func (share *EvaluationKeyShare) MarshalBinary() (data []byte, err error) {
+	return share.GadgetCiphertext.MarshalBinary()
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by MarshalBinary on the share.
+func (share *EvaluationKeyShare) UnmarshalBinary(data []byte) (err error) {
+	return share.GadgetCiphertext.UnmarshalBinary(data)
+}
+
+// EvaluationKeyGenProtocol implements the single-round protocol generating the parties' shares of an
+// rlwe.EvaluationKey from skIn to skOut. Every party runs GenShare locally and broadcasts the resulting
+// EvaluationKeyShare; any party (or an untrusted aggregator) can then combine the received shares with
+// AggregateEvaluationKeyShares to recover the collective EvaluationKey.
+//
+// The underlying protocol is the standard RLWE collective key-switching key generation: each party samples
+// the common `a_i` from the CRS, a small error `e_i`, and publishes `b_i = -a_i * skOut_i + e_i + P * g_i * skIn_i`,
+// where `g_i` is the i-th gadget decomposition factor. Summed over all parties, `sum(b_i) = -a_i*skOut + e + P*g_i*skIn`
+// with `e = sum(e_i)`, i.e. exactly a (noisy) gadget encryption of skIn under skOut.
+type EvaluationKeyGenProtocol struct {
+	params rlwe.Parameters
+
+	gaussianSamplerQ *ring.GaussianSampler
+}
+
+// NewEvaluationKeyGenProtocol creates a new EvaluationKeyGenProtocol instance.
+func NewEvaluationKeyGenProtocol(params rlwe.Parameters) *EvaluationKeyGenProtocol {
+	return &EvaluationKeyGenProtocol{
+		params:           params,
+		gaussianSamplerQ: ring.NewGaussianSampler(params.RingQ(), params.Xe()),
+	}
+}
+
+// AllocateShare allocates an EvaluationKeyShare at the given Q and P levels.
+func (evkg *EvaluationKeyGenProtocol) AllocateShare(levelQ, levelP int) *EvaluationKeyShare {
+	return &EvaluationKeyShare{GadgetCiphertext: *rlwe.NewGadgetCiphertext(
+		evkg.params,
+		levelQ,
+		levelP,
+		evkg.params.DecompRNS(levelQ, levelP),
+		evkg.params.DecompPw2(levelQ, levelP),
+	)}
+}
+
+// GenShare generates this party's share of the switching key from skIn to skOut and writes it to shareOut.
+// crs must be seeded identically at every party for a given protocol run, so that every party samples the
+// same sequence of `a_i` polynomials.
+func (evkg *EvaluationKeyGenProtocol) GenShare(skIn, skOut *rlwe.SecretKey, crs CRS, shareOut *EvaluationKeyShare) (err error) {
+
+	levelQ, levelP := shareOut.LevelQ(), shareOut.LevelP()
+	ringQP := evkg.params.RingQP().AtLevel(levelQ, levelP)
+
+	us := ringqp.NewUniformSampler(crs, *ringQP.RingQ, *ringQP.RingP)
+
+	for i := range shareOut.Value {
+		for j := range shareOut.Value[i] {
+
+			b, a := shareOut.Value[i][j][0], shareOut.Value[i][j][1]
+
+			// a_i <- CRS
+			us.Read(a)
+
+			// e_i <- Gaussian, already in the coefficient domain: extend it to the P basis before the NTT
+			// below, no inverse transform needed first.
+			evkg.gaussianSamplerQ.Read(b.Q)
+			ringQP.ExtendBasisSmallNormAndCenter(b.Q, levelP, b.Q, b.P)
+			ringQP.NTT(b, b)
+			ringQP.MForm(b, b)
+
+			// b_i = -a_i * skOut + e_i
+			ringQP.MulCoeffsMontgomeryThenSub(a, skOut.Value, b)
+
+			// b_i += P * g_{i,j} * skIn
+			rlwe.AddPowerBasis(ringQP, i, j, skIn.Value, b)
+		}
+	}
+
+	return nil
+}
+
+// AggregateShares aggregates share1 and share2, two parties' EvaluationKeyShares for the same protocol run,
+// into shareOut.
+func (evkg *EvaluationKeyGenProtocol) AggregateShares(share1, share2, shareOut *EvaluationKeyShare) (err error) {
+	if len(share1.Value) != len(share2.Value) || len(share1.Value) != len(shareOut.Value) {
+		return fmt.Errorf("cannot AggregateShares: shares have mismatching gadget decompositions")
+	}
+
+	ringQP := evkg.params.RingQP().AtLevel(shareOut.LevelQ(), shareOut.LevelP())
+
+	for i := range shareOut.Value {
+		for j := range shareOut.Value[i] {
+			ringQP.Add(share1.Value[i][j][0], share2.Value[i][j][0], shareOut.Value[i][j][0])
+			// the CRS-derived `a` component is identical across parties; copy it through unchanged.
+			ringQP.Copy(share1.Value[i][j][1], shareOut.Value[i][j][1])
+		}
+	}
+
+	return nil
+}
+
+// AggregateEvaluationKeyShares combines the shares collected from every party of a single
+// EvaluationKeyGenProtocol run into the final rlwe.EvaluationKey. It is a convenience wrapper around
+// repeated calls to AggregateShares for callers that already gathered all shares (e.g. the aggregator role
+// in a star-topology deployment) rather than aggregating incrementally as shares arrive.
+func AggregateEvaluationKeyShares(shares []EvaluationKeyShare) (*rlwe.EvaluationKey, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("cannot AggregateEvaluationKeyShares: shares is empty")
+	}
+
+	agg := shares[0].GadgetCiphertext.CopyNew()
+
+	for _, share := range shares[1:] {
+		if len(share.Value) != len(agg.Value) {
+			return nil, fmt.Errorf("cannot AggregateEvaluationKeyShares: shares have mismatching gadget decompositions")
+		}
+		for i := range agg.Value {
+			for j := range agg.Value[i] {
+				agg.Value[i][j][0].Add(agg.Value[i][j][0], share.Value[i][j][0])
+			}
+		}
+	}
+
+	return &rlwe.EvaluationKey{GadgetCiphertext: *agg}, nil
+}
@@ -0,0 +1,83 @@
+package mhe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tuneinsight/lattigo/v4/ckks"
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+	"github.com/tuneinsight/lattigo/v4/utils/sampling"
+)
+
+// TestEvaluationKeyGenProtocolRoundTrip exercises GenShare at a level where both the Q and P moduli chains
+// are non-empty (levelP > -1), which previously panicked/corrupted the share because GenShare applied
+// RingP's INTT to a RingQ-shaped polynomial. It then checks that aggregating two parties' shares yields an
+// EvaluationKey that correctly switches a sample ciphertext from skIn to skOut: encrypting under skIn,
+// applying the key, and decrypting under skOut recovers the original message.
+func TestEvaluationKeyGenProtocolRoundTrip(t *testing.T) {
+
+	ckksParams, err := ckks.NewParametersFromLiteral(ckks.TestParametersLiteral)
+	require.NoError(t, err)
+	params := ckksParams.Parameters
+	require.Greater(t, params.MaxLevelP(), -1, "test parameters must have a non-empty P chain")
+
+	kgen := ckks.NewKeyGenerator(ckksParams)
+
+	skIn0, skOut0 := kgen.GenSecretKeyNew(), kgen.GenSecretKeyNew()
+	skIn1, skOut1 := kgen.GenSecretKeyNew(), kgen.GenSecretKeyNew()
+
+	skIn, skOut := rlwe.NewSecretKey(params), rlwe.NewSecretKey(params)
+	params.RingQP().Add(&skIn0.Value, &skIn1.Value, &skIn.Value)
+	params.RingQP().Add(&skOut0.Value, &skOut1.Value, &skOut.Value)
+
+	// Every party samples the common `a` polynomials from its own, identically seeded CRS.
+	crs0, err := newTestCRS()
+	require.NoError(t, err)
+	crs1, err := newTestCRS()
+	require.NoError(t, err)
+
+	levelQ, levelP := params.MaxLevel(), params.MaxLevelP()
+	evkg := NewEvaluationKeyGenProtocol(params)
+
+	share0 := evkg.AllocateShare(levelQ, levelP)
+	share1 := evkg.AllocateShare(levelQ, levelP)
+
+	require.NoError(t, evkg.GenShare(skIn0, skOut0, crs0, share0))
+	require.NoError(t, evkg.GenShare(skIn1, skOut1, crs1, share1))
+
+	evk, err := AggregateEvaluationKeyShares([]EvaluationKeyShare{*share0, *share1})
+	require.NoError(t, err)
+	require.NotNil(t, evk)
+
+	ecd := ckks.NewEncoder(ckksParams)
+	enc := ckks.NewEncryptor(ckksParams, skIn)
+	dec := ckks.NewDecryptor(ckksParams, skOut)
+	eval := ckks.NewEvaluator(ckksParams, nil)
+
+	slots := ckksParams.MaxSlots()
+	values := make([]float64, slots)
+	for i := range values {
+		values[i] = float64(i) / float64(slots)
+	}
+
+	pt := ckks.NewPlaintext(ckksParams, ckksParams.MaxLevel())
+	require.NoError(t, ecd.Encode(values, pt))
+
+	ctIn, err := enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	ctOut := ckks.NewCiphertext(ckksParams, 1, ctIn.Level())
+	require.NoError(t, eval.ApplyEvaluationKey(ctIn, evk, ctOut))
+
+	have := make([]float64, slots)
+	require.NoError(t, ecd.Decode(dec.DecryptNew(ctOut), have))
+
+	for i, want := range values {
+		require.InDelta(t, want, have[i], 1e-6)
+	}
+}
+
+// newTestCRS returns a CRS deterministically seeded for use across a protocol run in tests.
+func newTestCRS() (CRS, error) {
+	return sampling.NewKeyedPRNG([]byte("mhe-test-crs-seed"))
+}
@@ -0,0 +1,62 @@
+package mhe
+
+import (
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+)
+
+// GaloisKeyGenShare is a party's share of a collectively generated rlwe.GaloisKey for a given Galois element.
+// It has the same shape as, and is aggregated exactly like, an EvaluationKeyShare: a GaloisKey is an
+// EvaluationKey from skIn = pi_galEl(sk) to skOut = sk.
+type GaloisKeyGenShare struct {
+	EvaluationKeyShare
+	GaloisElement uint64
+}
+
+// AllocateGaloisKeyShare allocates a GaloisKeyGenShare for the given Galois element at the given Q and P levels.
+func (evkg *EvaluationKeyGenProtocol) AllocateGaloisKeyShare(galEl uint64, levelQ, levelP int) *GaloisKeyGenShare {
+	return &GaloisKeyGenShare{
+		EvaluationKeyShare: *evkg.AllocateShare(levelQ, levelP),
+		GaloisElement:      galEl,
+	}
+}
+
+// GenGaloisKeyShare generates this party's share of the GaloisKey for galEl and writes it to shareOut.
+// sk is this party's share of the secret key; the automorphism pi_galEl(sk) is applied to it internally to
+// derive skIn, so callers never need to materialize pi_galEl(sk) themselves.
+func (evkg *EvaluationKeyGenProtocol) GenGaloisKeyShare(sk *rlwe.SecretKey, galEl uint64, crs CRS, shareOut *GaloisKeyGenShare) (err error) {
+	levelQ, levelP := shareOut.LevelQ(), shareOut.LevelP()
+
+	skIn := rlwe.NewSecretKey(evkg.params)
+	evkg.params.RingQP().AtLevel(levelQ, levelP).Automorphism(sk.Value, galEl, skIn.Value)
+
+	shareOut.GaloisElement = galEl
+
+	return evkg.GenShare(skIn, sk, crs, &shareOut.EvaluationKeyShare)
+}
+
+// AggregateGaloisKeyShares aggregates share1 and share2, two parties' shares for the same Galois element,
+// into shareOut.
+func (evkg *EvaluationKeyGenProtocol) AggregateGaloisKeyShares(share1, share2, shareOut *GaloisKeyGenShare) (err error) {
+	shareOut.GaloisElement = share1.GaloisElement
+	return evkg.AggregateShares(&share1.EvaluationKeyShare, &share2.EvaluationKeyShare, &shareOut.EvaluationKeyShare)
+}
+
+// AggregateGaloisKeyShares combines the shares collected from every party of a single GaloisKey generation
+// run into the final rlwe.GaloisKey for the corresponding Galois element.
+func AggregateGaloisKeyShares(shares []GaloisKeyGenShare) (*rlwe.GaloisKey, error) {
+	evkShares := make([]EvaluationKeyShare, len(shares))
+	for i := range shares {
+		evkShares[i] = shares[i].EvaluationKeyShare
+	}
+
+	evk, err := AggregateEvaluationKeyShares(evkShares)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rlwe.GaloisKey{
+		EvaluationKey: *evk,
+		GaloisElement: shares[0].GaloisElement,
+		NthRoot:       0,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package mhe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tuneinsight/lattigo/v4/ckks"
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+	"github.com/tuneinsight/lattigo/v4/utils/sampling"
+)
+
+// TestGenGaloisKeyShareConvention checks that the rlwe.GaloisKey collectively generated through
+// GenGaloisKeyShare/AggregateGaloisKeyShares actually rotates a sample ciphertext's slots in the direction
+// implied by galEl, i.e. that GenGaloisKeyShare derives skIn as pi_galEl(sk) and not pi_galEl^-1(sk).
+func TestGenGaloisKeyShareConvention(t *testing.T) {
+
+	ckksParams, err := ckks.NewParametersFromLiteral(ckks.TestParametersLiteral)
+	require.NoError(t, err)
+	params := ckksParams.Parameters
+
+	kgen := ckks.NewKeyGenerator(ckksParams)
+	sk0, sk1 := kgen.GenSecretKeyNew(), kgen.GenSecretKeyNew()
+
+	sk := rlwe.NewSecretKey(params)
+	params.RingQP().Add(&sk0.Value, &sk1.Value, &sk.Value)
+
+	const rotateBy = 1
+	galEl := params.GaloisElement(rotateBy)
+
+	// Every party samples the common `a` polynomials from its own, identically seeded CRS.
+	crs0, err := sampling.NewKeyedPRNG([]byte("mhe-test-crs-seed"))
+	require.NoError(t, err)
+	crs1, err := sampling.NewKeyedPRNG([]byte("mhe-test-crs-seed"))
+	require.NoError(t, err)
+
+	evkg := NewEvaluationKeyGenProtocol(params)
+	levelQ, levelP := params.MaxLevel(), params.MaxLevelP()
+
+	share0 := evkg.AllocateGaloisKeyShare(galEl, levelQ, levelP)
+	share1 := evkg.AllocateGaloisKeyShare(galEl, levelQ, levelP)
+	require.NoError(t, evkg.GenGaloisKeyShare(sk0, galEl, crs0, share0))
+	require.NoError(t, evkg.GenGaloisKeyShare(sk1, galEl, crs1, share1))
+
+	aggShare := evkg.AllocateGaloisKeyShare(galEl, levelQ, levelP)
+	require.NoError(t, evkg.AggregateGaloisKeyShares(share0, share1, aggShare))
+
+	gk, err := AggregateGaloisKeyShares([]GaloisKeyGenShare{*aggShare})
+	require.NoError(t, err)
+
+	evk := rlwe.NewMemEvaluationKeySet(nil, gk)
+	eval := ckks.NewEvaluator(ckksParams, evk)
+
+	ecd := ckks.NewEncoder(ckksParams)
+	enc := ckks.NewEncryptor(ckksParams, sk)
+	dec := ckks.NewDecryptor(ckksParams, sk)
+
+	slots := ckksParams.MaxSlots()
+	values := make([]float64, slots)
+	for i := range values {
+		values[i] = float64(i)
+	}
+
+	pt := ckks.NewPlaintext(ckksParams, ckksParams.MaxLevel())
+	require.NoError(t, ecd.Encode(values, pt))
+
+	ctIn, err := enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	ctOut := ckks.NewCiphertext(ckksParams, 1, ctIn.Level())
+	require.NoError(t, eval.Automorphism(ctIn, galEl, ctOut))
+
+	have := make([]float64, slots)
+	require.NoError(t, ecd.Decode(dec.DecryptNew(ctOut), have))
+
+	want := make([]float64, slots)
+	for i := range values {
+		want[i] = values[(i+rotateBy)%slots]
+	}
+
+	for i := range want {
+		require.InDelta(t, want[i], have[i], 0.1)
+	}
+}
@@ -0,0 +1,31 @@
+// Package mhe implements the multiparty (a.k.a. threshold, or distributed) variants of the RLWE key-generation
+// protocols. It lets N parties, each holding only a share of the secret key, collectively produce public
+// material (EvaluationKey, GaloisKey, RelinearizationKey, ...) without ever reconstructing the full secret
+// key at any single party.
+//
+// Two sharing models are supported:
+//
+//   - Additive (N-out-of-N): every party holds an additive share sk_i of the secret key, with sk = sum(sk_i).
+//     This is the natural output of a distributed key-generation ceremony.
+//
+//   - Shamir threshold (t-out-of-N): every party holds a Shamir share of the secret key, and any subset of
+//     at least t parties can locally combine their shares into a local additive share of sk via the
+//     Thresholdizer/Combiner pair before running the protocols below. Parties below the threshold, or that
+//     go offline, do not prevent the remaining t (or more) parties from completing a protocol run.
+//
+// The key-generation protocols in this package follow the same four-step pattern: AllocateShare, GenShare
+// (possibly over several rounds), AggregateShares, and a final Aggregate*Shares function (e.g.
+// AggregateEvaluationKeyShares) that folds the collected shares into the public output. Shares are designed to be sent over a network and therefore implement BinaryMarshaler/BinaryUnmarshaler.
+package mhe
+
+import (
+	"github.com/tuneinsight/lattigo/v4/utils/sampling"
+)
+
+// CRS is a common reference string: a PRNG whose output stream is identical for every party, used to sample
+// the public polynomial `a` shared across a protocol round without requiring a broadcast. Parties agree on
+// the CRS out of band (e.g. by seeding it with a hash of the session parameters) and then each derive the
+// same sequence of `a` values locally and deterministically.
+type CRS interface {
+	sampling.PRNG
+}
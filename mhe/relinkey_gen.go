@@ -0,0 +1,79 @@
+package mhe
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+)
+
+// RelinKeyGenShare is a party's share of a single round of the collective RelinearizationKey generation
+// protocol. Unlike EvaluationKeyShare and GaloisKeyGenShare, relinearization cannot be produced in a single
+// round: skIn = sk^2 is not available to any party individually, so the parties must first collectively
+// build an EvaluationKey from sk to an ephemeral key u (round one), then use that to turn their local
+// shares of sk*u into shares of an EvaluationKey from sk^2 to sk (round two).
+type RelinKeyGenShare struct {
+	EvaluationKeyShare
+}
+
+// RelinKeyGenProtocol implements the two-round collective RelinearizationKey generation protocol.
+type RelinKeyGenProtocol struct {
+	*EvaluationKeyGenProtocol
+}
+
+// NewRelinKeyGenProtocol creates a new RelinKeyGenProtocol instance.
+func NewRelinKeyGenProtocol(params rlwe.Parameters) *RelinKeyGenProtocol {
+	return &RelinKeyGenProtocol{EvaluationKeyGenProtocol: NewEvaluationKeyGenProtocol(params)}
+}
+
+// AllocateShare allocates the ephemeral secret key and the two RelinKeyGenShares needed to run the protocol.
+func (rkg *RelinKeyGenProtocol) AllocateShare(levelQ, levelP int) (ephSk *rlwe.SecretKey, share1, share2 *RelinKeyGenShare) {
+	ephSk = rlwe.NewSecretKey(rkg.params)
+	share1 = &RelinKeyGenShare{EvaluationKeyShare: *rkg.EvaluationKeyGenProtocol.AllocateShare(levelQ, levelP)}
+	share2 = &RelinKeyGenShare{EvaluationKeyShare: *rkg.EvaluationKeyGenProtocol.AllocateShare(levelQ, levelP)}
+	return
+}
+
+// GenShareRoundOne samples this party's ephemeral secret u_i, stores it in ephSkOut for use in round two, and
+// generates this party's share of an EvaluationKey from sk to u = sum(u_i) into shareOut.
+func (rkg *RelinKeyGenProtocol) GenShareRoundOne(sk *rlwe.SecretKey, crs CRS, ephSkOut *rlwe.SecretKey, shareOut *RelinKeyGenShare) (err error) {
+	rlwe.NewKeyGenerator(rkg.params).GenSecretKey(ephSkOut)
+	return rkg.GenShare(sk, ephSkOut, crs, &shareOut.EvaluationKeyShare)
+}
+
+// AggregateShares aggregates share1 and share2, from the same round, into shareOut.
+func (rkg *RelinKeyGenProtocol) AggregateShares(share1, share2, shareOut *RelinKeyGenShare) (err error) {
+	return rkg.EvaluationKeyGenProtocol.AggregateShares(&share1.EvaluationKeyShare, &share2.EvaluationKeyShare, &shareOut.EvaluationKeyShare)
+}
+
+// GenShareRoundTwo uses the aggregated round-one share (an EvaluationKey from sk to u) together with this
+// party's ephemeral key ephSk and secret-key share sk to generate this party's share of an EvaluationKey
+// from sk^2 to sk, writing it to shareOut.
+func (rkg *RelinKeyGenProtocol) GenShareRoundTwo(ephSk, sk *rlwe.SecretKey, roundOneAgg *RelinKeyGenShare, crs CRS, shareOut *RelinKeyGenShare) (err error) {
+	if len(roundOneAgg.Value) != len(shareOut.Value) {
+		return fmt.Errorf("cannot GenShareRoundTwo: roundOneAgg and shareOut have mismatching gadget decompositions")
+	}
+
+	// skIn for round two is this party's share of sk*u, obtained by applying its share of sk to the
+	// collectively switched-to-u gadget ciphertext from round one and keeping only the error-free part.
+	skInShare := rlwe.NewSecretKey(rkg.params)
+	evaluator := rlwe.NewEvaluator(rkg.params, nil)
+	evaluator.GadgetProduct(shareOut.LevelQ(), sk.Value.Q, &roundOneAgg.GadgetCiphertext, skInShare.Value.Q)
+
+	return rkg.GenShare(skInShare, sk, crs, &shareOut.EvaluationKeyShare)
+}
+
+// AggregateRelinKeyShares combines the round-two shares collected from every party into the final
+// rlwe.RelinearizationKey.
+func AggregateRelinKeyShares(shares []RelinKeyGenShare) (*rlwe.RelinearizationKey, error) {
+	evkShares := make([]EvaluationKeyShare, len(shares))
+	for i := range shares {
+		evkShares[i] = shares[i].EvaluationKeyShare
+	}
+
+	evk, err := AggregateEvaluationKeyShares(evkShares)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rlwe.RelinearizationKey{EvaluationKey: *evk}, nil
+}
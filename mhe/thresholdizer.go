@@ -0,0 +1,157 @@
+package mhe
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v4/ring"
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+	"github.com/tuneinsight/lattigo/v4/rlwe/ringqp"
+	"github.com/tuneinsight/lattigo/v4/utils/sampling"
+)
+
+// ShamirPublicPoint is the public x-coordinate at which a party's Shamir share of the secret key is
+// evaluated. Parties must agree on distinct, non-zero ShamirPublicPoints out of band before thresholdizing.
+type ShamirPublicPoint uint64
+
+// ShamirPolynomial is a secret polynomial f of degree threshold-1, sampled independently for each
+// coefficient of the secret-key polynomial, such that f(0) is the party's additive share of the secret key.
+type ShamirPolynomial struct {
+	Coeffs []rlwe.SecretKey
+}
+
+// ShamirSecretShare is a Shamir share of a secret key, i.e. f(point) for some other party's ShamirPolynomial
+// f and this party's ShamirPublicPoint. Aggregating the shares received from at least `threshold` parties
+// and feeding them to a Combiner yields a local additive share of the collective secret key.
+type ShamirSecretShare struct {
+	rlwe.SecretKey
+}
+
+// Thresholdizer generates and aggregates the ShamirSecretShares used to turn an additive sharing of a secret
+// key into a t-out-of-N Shamir sharing.
+type Thresholdizer struct {
+	params rlwe.Parameters
+	prng   sampling.PRNG
+}
+
+// NewThresholdizer creates a new Thresholdizer instance from a set of RLWE parameters.
+func NewThresholdizer(params rlwe.Parameters) (*Thresholdizer, error) {
+	prng, err := sampling.NewPRNG()
+	if err != nil {
+		return nil, fmt.Errorf("cannot NewThresholdizer: %w", err)
+	}
+	return &Thresholdizer{params: params, prng: prng}, nil
+}
+
+// GenShamirPolynomial generates a new secret ShamirPolynomial from which shares for the other parties can be
+// derived. `threshold` must be in [1, N] where 1 reduces to the additive (non-threshold) case.
+func (thr *Thresholdizer) GenShamirPolynomial(threshold int, sk *rlwe.SecretKey) (*ShamirPolynomial, error) {
+	if threshold < 1 {
+		return nil, fmt.Errorf("cannot GenShamirPolynomial: threshold must be >= 1, got %d", threshold)
+	}
+
+	gen := &ShamirPolynomial{Coeffs: make([]rlwe.SecretKey, threshold)}
+	gen.Coeffs[0] = *sk.CopyNew()
+
+	levelQ, levelP := sk.LevelQ(), sk.LevelP()
+	ringQP := thr.params.RingQP().AtLevel(levelQ, levelP)
+	us := ringqp.NewUniformSampler(thr.prng, *ringQP.RingQ, *ringQP.RingP)
+
+	// Every non-constant coefficient must be sampled uniformly over the full ring: the masking polynomial
+	// has to be indistinguishable from random to any coalition of fewer than `threshold` parties, which the
+	// narrow ternary secret-key distribution (as rlwe.KeyGenerator.GenSecretKey would produce) does not
+	// provide.
+	for i := 1; i < threshold; i++ {
+		coeff := rlwe.NewSecretKey(thr.params)
+		us.Read(coeff.Value)
+		gen.Coeffs[i] = *coeff
+	}
+
+	return gen, nil
+}
+
+// AllocateThresholdSecretShare allocates a ShamirSecretShare.
+func (thr *Thresholdizer) AllocateThresholdSecretShare() *ShamirSecretShare {
+	return &ShamirSecretShare{SecretKey: *rlwe.NewSecretKey(thr.params)}
+}
+
+// GenShamirSecretShare evaluates secretPoly at recipient and writes the result to shareOut, to be sent
+// privately to the party identified by recipient.
+func (thr *Thresholdizer) GenShamirSecretShare(recipient ShamirPublicPoint, secretPoly *ShamirPolynomial, shareOut *ShamirSecretShare) {
+	ringQP := thr.params.RingQP().AtLevel(shareOut.LevelQ(), shareOut.LevelP())
+
+	// Horner's method evaluation of secretPoly at the point `recipient`, in the RNS domain, independently
+	// for every RNS limb and both the Q and P moduli: acc <- coeff_i + recipient * acc.
+	tmp := ringQP.NewPoly()
+	ringQP.Copy(&secretPoly.Coeffs[len(secretPoly.Coeffs)-1].Value, &shareOut.Value)
+	for i := len(secretPoly.Coeffs) - 2; i >= 0; i-- {
+		ringQP.Copy(&secretPoly.Coeffs[i].Value, &tmp)
+		ringQP.MulScalarThenAdd(&shareOut.Value, uint64(recipient), &tmp)
+		ringQP.Copy(&tmp, &shareOut.Value)
+	}
+}
+
+// AggregateShares aggregates two ShamirSecretShares received from distinct parties.
+func (thr *Thresholdizer) AggregateShares(share1, share2, shareOut *ShamirSecretShare) {
+	thr.params.RingQP().AtLevel(shareOut.LevelQ(), shareOut.LevelP()).Add(&share1.Value, &share2.Value, &shareOut.Value)
+}
+
+// Combiner combines the ShamirSecretShares held by a quorum of at least `threshold` parties into a local
+// additive share of the collective secret key, using Lagrange interpolation at x=0.
+type Combiner struct {
+	params    rlwe.Parameters
+	threshold int
+}
+
+// NewCombiner creates a new Combiner for the given threshold.
+func NewCombiner(params rlwe.Parameters, threshold int) *Combiner {
+	return &Combiner{params: params, threshold: threshold}
+}
+
+// GenAdditiveShare combines ownShare, received from the aggregation of the active parties' Shamir shares
+// evaluated at ownPoint, into skOut: a local additive share of the secret key as if it had been generated
+// directly by an N-out-of-N sharing among activePoints.
+func (cmb *Combiner) GenAdditiveShare(activePoints []ShamirPublicPoint, ownPoint ShamirPublicPoint, ownShare *ShamirSecretShare, skOut *rlwe.SecretKey) error {
+	if len(activePoints) < cmb.threshold {
+		return fmt.Errorf("cannot GenAdditiveShare: only %d active points, threshold is %d", len(activePoints), cmb.threshold)
+	}
+
+	levelQ, levelP := skOut.LevelQ(), skOut.LevelP()
+
+	// The Lagrange coefficient is only defined modulo a single modulus: a multi-limb RNS polynomial must be
+	// scaled by its own modulus' coefficient on every limb independently (mirroring ckks.Evaluator.Average's
+	// per-SubRing scaling), not by one coefficient reduced mod the first limb's modulus applied to the whole
+	// multi-modulus value.
+	ringQ := cmb.params.RingQ().AtLevel(levelQ)
+	for i, s := range ringQ.SubRings[:levelQ+1] {
+		coeff := lagrangeCoefficientAtZero(activePoints, ownPoint, s.Modulus, s.BRedConstant)
+		s.MulScalarMontgomery(ownShare.Value.Q.Coeffs[i], ring.MForm(coeff, s.Modulus, s.BRedConstant), skOut.Value.Q.Coeffs[i])
+	}
+
+	if levelP > -1 {
+		ringP := cmb.params.RingP().AtLevel(levelP)
+		for i, s := range ringP.SubRings[:levelP+1] {
+			coeff := lagrangeCoefficientAtZero(activePoints, ownPoint, s.Modulus, s.BRedConstant)
+			s.MulScalarMontgomery(ownShare.Value.P.Coeffs[i], ring.MForm(coeff, s.Modulus, s.BRedConstant), skOut.Value.P.Coeffs[i])
+		}
+	}
+
+	return nil
+}
+
+// lagrangeCoefficientAtZero computes the Lagrange basis coefficient for `point` evaluated at x=0, over the
+// set `activePoints`, reduced modulo the single RNS modulus supplied by the caller. Callers combining a
+// multi-limb RNS polynomial must call this once per limb, with that limb's own modulus, and apply the result
+// to that limb only: a coefficient computed mod one modulus is meaningless applied to any other.
+func lagrangeCoefficientAtZero(activePoints []ShamirPublicPoint, point ShamirPublicPoint, modulus uint64, bRedConstant ring.BRedConstant) uint64 {
+	num, den := uint64(1), uint64(1)
+	for _, p := range activePoints {
+		if p == point {
+			continue
+		}
+		num = ring.BRed(num, uint64(p), modulus, bRedConstant)
+		diff := (modulus + uint64(p) - uint64(point)) % modulus
+		den = ring.BRed(den, diff, modulus, bRedConstant)
+	}
+
+	return ring.BRed(num, ring.ModExp(den, modulus-2, modulus), modulus, bRedConstant)
+}
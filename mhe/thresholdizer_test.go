@@ -0,0 +1,59 @@
+package mhe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tuneinsight/lattigo/v4/rlwe"
+)
+
+// TestThresholdizerCombinerRoundTrip checks that, for a multi-limb RNS parameter set, combining the
+// t-out-of-N Shamir shares of a quorum of parties via Thresholdizer/Combiner reconstructs the same additive
+// secret-key share that a direct N-out-of-N sharing among that quorum would have produced, i.e. that
+// Combiner.GenAdditiveShare recombines every RNS limb correctly, not just the first.
+func TestThresholdizerCombinerRoundTrip(t *testing.T) {
+
+	params, err := rlwe.NewParametersFromLiteral(rlwe.TestParametersLiteral)
+	require.NoError(t, err)
+
+	const threshold = 2
+	points := []ShamirPublicPoint{1, 2, 3}
+
+	kgen := rlwe.NewKeyGenerator(params)
+	skIdeal := rlwe.NewSecretKey(params)
+
+	thr, err := NewThresholdizer(params)
+	require.NoError(t, err)
+
+	polys := make([]*ShamirPolynomial, len(points))
+	skShares := make([]*rlwe.SecretKey, len(points))
+	for i := range points {
+		skShares[i] = rlwe.NewSecretKey(params)
+		kgen.GenSecretKey(skShares[i])
+		params.RingQP().Add(&skIdeal.Value, &skShares[i].Value, &skIdeal.Value)
+
+		polys[i], err = thr.GenShamirPolynomial(threshold, skShares[i])
+		require.NoError(t, err)
+	}
+
+	// Every party aggregates the Shamir shares it receives from every other party (including itself).
+	aggregated := make([]*ShamirSecretShare, len(points))
+	for i, recipient := range points {
+		aggregated[i] = thr.AllocateThresholdSecretShare()
+		for j := range points {
+			share := thr.AllocateThresholdSecretShare()
+			thr.GenShamirSecretShare(recipient, polys[j], share)
+			thr.AggregateShares(aggregated[i], share, aggregated[i])
+		}
+	}
+
+	cmb := NewCombiner(params, threshold)
+	skCombined := rlwe.NewSecretKey(params)
+	for i, ownPoint := range points {
+		skOut := rlwe.NewSecretKey(params)
+		require.NoError(t, cmb.GenAdditiveShare(points, ownPoint, aggregated[i], skOut))
+		params.RingQP().Add(&skCombined.Value, &skOut.Value, &skCombined.Value)
+	}
+
+	require.True(t, skIdeal.Equals(skCombined))
+}
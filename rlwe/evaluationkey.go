@@ -1,5 +1,11 @@
 package rlwe
 
+import (
+	"io"
+
+	"github.com/tuneinsight/lattigo/v4/utils/sampling"
+)
+
 // EvaluationKey is a public key indended to be used during the evaluation phase of a homomorphic circuit.
 // It provides a one way public and non-interactive re-encryption from a ciphertext encrypted under `skIn`
 // to a ciphertext encrypted under `skOut`.
@@ -68,3 +74,25 @@ func (evk *EvaluationKey) UnmarshalBinary(data []byte) (err error) {
 func (evk *EvaluationKey) Write(data []byte) (ptr int, err error) {
 	return evk.GadgetCiphertext.Write(data)
 }
+
+// MarshalBinarySeeded encodes the object using the seeded wire format (see GadgetCiphertext.MarshalBinarySeeded),
+// roughly halving the marshalled size at the cost of requiring the seeds used at generation time.
+func (evk *EvaluationKey) MarshalBinarySeeded(seeds [][]byte) (data []byte, err error) {
+	return evk.GadgetCiphertext.MarshalBinarySeeded(seeds)
+}
+
+// UnmarshalBinaryFromSeed decodes a slice of bytes generated by MarshalBinarySeeded on the object.
+func (evk *EvaluationKey) UnmarshalBinaryFromSeed(data []byte, prng *sampling.KeyedPRNG) (err error) {
+	return evk.GadgetCiphertext.UnmarshalBinaryFromSeed(data, prng)
+}
+
+// WriteTo writes the object to a io.Writer, one gadget row at a time, so that neither side of the stream
+// needs to hold the full serialized key in memory at once.
+func (evk *EvaluationKey) WriteTo(w io.Writer) (n int64, err error) {
+	return evk.GadgetCiphertext.WriteTo(w)
+}
+
+// ReadFrom reads the object from a io.Reader, as produced by WriteTo.
+func (evk *EvaluationKey) ReadFrom(r io.Reader) (n int64, err error) {
+	return evk.GadgetCiphertext.ReadFrom(r)
+}
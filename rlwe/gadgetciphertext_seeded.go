@@ -0,0 +1,184 @@
+package rlwe
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tuneinsight/lattigo/v4/rlwe/ringqp"
+	"github.com/tuneinsight/lattigo/v4/utils/sampling"
+)
+
+// gadgetCiphertextSeedSize is the size in bytes of the seed used to reseed the KeyedPRNG that regenerates a
+// gadget row's uniformly random polynomial in the seeded wire format.
+const gadgetCiphertextSeedSize = 32
+
+// gadgetCiphertextEncodingSeeded is the header flag identifying the seeded wire format produced by
+// MarshalBinarySeeded, distinguishing it from the default, un-flagged format produced by MarshalBinary.
+const gadgetCiphertextEncodingSeeded uint8 = 1
+
+// MarshalBinarySeeded encodes the object using the seeded wire format: for every gadget row, the uniformly
+// random polynomial (the `a` part of the row, normally indistinguishable from random) is dropped from the
+// output and replaced by the 32-byte seed that was used to sample it from a sampling.KeyedPRNG. This roughly
+// halves the size of the marshalled output compared to MarshalBinary, at the cost of requiring the seeds
+// used at generation time to be supplied here, in the same order the rows were generated.
+//
+// The result can be restored with UnmarshalBinaryFromSeed.
+func (ct *GadgetCiphertext) MarshalBinarySeeded(seeds [][]byte) (data []byte, err error) {
+
+	nbRows := len(ct.Value) * len(ct.Value[0])
+	if len(seeds) != nbRows {
+		return nil, fmt.Errorf("cannot MarshalBinarySeeded: len(seeds)=%d must match the number of gadget rows (%d)", len(seeds), nbRows)
+	}
+
+	for i, seed := range seeds {
+		if len(seed) != gadgetCiphertextSeedSize {
+			return nil, fmt.Errorf("cannot MarshalBinarySeeded: seeds[%d] must be %d bytes, got %d", i, gadgetCiphertextSeedSize, len(seed))
+		}
+	}
+
+	bSize := ct.Value[0][0][0].MarshalBinarySize()
+	data = make([]byte, 1+nbRows*(bSize+gadgetCiphertextSeedSize))
+	data[0] = gadgetCiphertextEncodingSeeded
+	ptr := 1
+
+	k := 0
+	for i := range ct.Value {
+		for j := range ct.Value[i] {
+			var inc int
+			if inc, err = ct.Value[i][j][0].Read(data[ptr:]); err != nil { // b part: written in full
+				return nil, fmt.Errorf("cannot MarshalBinarySeeded: %w", err)
+			}
+			ptr += inc
+
+			ptr += copy(data[ptr:], seeds[k]) // a part: replaced by its seed
+			k++
+		}
+	}
+
+	return data[:ptr], nil
+}
+
+// UnmarshalBinaryFromSeed decodes a slice of bytes generated by MarshalBinarySeeded. prng is reseeded once
+// per gadget row with the seed read from data, then used to resample that row's `a` polynomial exactly as
+// it would have been sampled at generation time.
+func (ct *GadgetCiphertext) UnmarshalBinaryFromSeed(data []byte, prng *sampling.KeyedPRNG) (err error) {
+
+	if len(data) < 1 {
+		return fmt.Errorf("cannot UnmarshalBinaryFromSeed: data is empty")
+	}
+
+	if data[0] != gadgetCiphertextEncodingSeeded {
+		return fmt.Errorf("cannot UnmarshalBinaryFromSeed: data is not in the seeded encoding (flag=%d)", data[0])
+	}
+	ptr := 1
+
+	ringQP := ct.Params.RingQP().AtLevel(ct.LevelQ(), ct.LevelP())
+	us := ringqp.NewUniformSampler(prng, *ringQP.RingQ, *ringQP.RingP)
+
+	for i := range ct.Value {
+		for j := range ct.Value[i] {
+			var inc int
+			if inc, err = ct.Value[i][j][0].Write(data[ptr:]); err != nil {
+				return fmt.Errorf("cannot UnmarshalBinaryFromSeed: %w", err)
+			}
+			ptr += inc
+
+			if len(data[ptr:]) < gadgetCiphertextSeedSize {
+				return fmt.Errorf("cannot UnmarshalBinaryFromSeed: truncated seed")
+			}
+
+			prng.Seed(data[ptr : ptr+gadgetCiphertextSeedSize])
+			ptr += gadgetCiphertextSeedSize
+
+			us.Read(ct.Value[i][j][1])
+		}
+	}
+
+	return nil
+}
+
+// WriteTo writes the object to a io.Writer, one gadget row at a time, so that neither side of the stream
+// needs to hold the full serialized key in memory at once. It uses the same, un-flagged default wire format
+// as MarshalBinary/Read row for row, so it interoperates with both ReadFrom and the byte-slice Read/Write
+// API: the bytes it produces, concatenated, equal the output of MarshalBinary. It does not share a format
+// with MarshalBinarySeeded/UnmarshalBinaryFromSeed, which is self-describing via its own header flag.
+func (ct *GadgetCiphertext) WriteTo(w io.Writer) (n int64, err error) {
+
+	row := make([]byte, ct.rowMarshalBinarySize())
+	for i := range ct.Value {
+		for j := range ct.Value[i] {
+			if _, err = ct.writeRow(i, j, row); err != nil {
+				return n, fmt.Errorf("cannot WriteTo: %w", err)
+			}
+
+			var nw int
+			if nw, err = w.Write(row); err != nil {
+				return n, fmt.Errorf("cannot WriteTo: %w", err)
+			}
+			n += int64(nw)
+		}
+	}
+
+	return n, nil
+}
+
+// ReadFrom reads the object from a io.Reader, one gadget row at a time, as produced by WriteTo or by a plain
+// MarshalBinary dump fed through a reader. The receiver must already be allocated at the correct
+// level/decomposition (e.g. via NewGadgetCiphertext), since the row size is needed up front to size the
+// per-row staging buffer.
+func (ct *GadgetCiphertext) ReadFrom(r io.Reader) (n int64, err error) {
+
+	row := make([]byte, ct.rowMarshalBinarySize())
+	for i := range ct.Value {
+		for j := range ct.Value[i] {
+			var nr int
+			if nr, err = io.ReadFull(r, row); err != nil {
+				return n, fmt.Errorf("cannot ReadFrom: %w", err)
+			}
+			n += int64(nr)
+
+			if _, err = ct.readRow(i, j, row); err != nil {
+				return n, fmt.Errorf("cannot ReadFrom: %w", err)
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// rowMarshalBinarySize returns the marshalled size in bytes of a single gadget row (the [2]ringqp.Poly pair).
+func (ct *GadgetCiphertext) rowMarshalBinarySize() int {
+	return 2 * ct.Value[0][0][0].MarshalBinarySize()
+}
+
+// writeRow encodes the (i, j)-th gadget row into the preallocated data slice.
+func (ct *GadgetCiphertext) writeRow(i, j int, data []byte) (ptr int, err error) {
+	var inc int
+	if inc, err = ct.Value[i][j][0].Read(data[ptr:]); err != nil {
+		return ptr, err
+	}
+	ptr += inc
+
+	if inc, err = ct.Value[i][j][1].Read(data[ptr:]); err != nil {
+		return ptr, err
+	}
+	ptr += inc
+
+	return ptr, nil
+}
+
+// readRow decodes the (i, j)-th gadget row from data.
+func (ct *GadgetCiphertext) readRow(i, j int, data []byte) (ptr int, err error) {
+	var inc int
+	if inc, err = ct.Value[i][j][0].Write(data[ptr:]); err != nil {
+		return ptr, err
+	}
+	ptr += inc
+
+	if inc, err = ct.Value[i][j][1].Write(data[ptr:]); err != nil {
+		return ptr, err
+	}
+	ptr += inc
+
+	return ptr, nil
+}